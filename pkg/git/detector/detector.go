@@ -6,12 +6,15 @@ package detector
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/redhat-developer/git-service/pkg/git"
 	"github.com/redhat-developer/git-service/pkg/git/repository"
-	"github.com/redhat-developer/git-service/pkg/git/repository/generic"
-	"github.com/redhat-developer/git-service/pkg/git/repository/github"
-	"github.com/redhat-developer/git-service/pkg/git/repository/gitlab"
+	_ "github.com/redhat-developer/git-service/pkg/git/repository/bitbucket"
+	_ "github.com/redhat-developer/git-service/pkg/git/repository/generic"
+	_ "github.com/redhat-developer/git-service/pkg/git/repository/github"
+	_ "github.com/redhat-developer/git-service/pkg/git/repository/gitlab"
+	"github.com/redhat-developer/git-service/pkg/git/repository/shallow"
 )
 
 const (
@@ -41,6 +44,14 @@ var buildToolsByFile = map[string]string{
 type BuildTool struct {
 	Name string
 	File string
+
+	// Manifest holds the parsed contents of File, populated only when the
+	// detector was run via DetectBuildEnvironmentsWithInspection with
+	// WithManifestInspection. Its concrete type depends on Name:
+	// *MavenManifest, *NodeJSManifest, *GoManifest or *DockerManifest. It is
+	// nil for build tools this detector doesn't parse deeper (Gradle,
+	// Python, Ruby) or when inspection wasn't requested.
+	Manifest interface{}
 }
 
 // BuildEnvStats is the outcome of inspecting a Source: the build tools
@@ -51,36 +62,93 @@ type BuildEnvStats struct {
 	SortedLanguages    []string
 }
 
-// allCreators is tried, in order, for every source. The generic-git fallback
-// is kept last since it matches any source none of the hosted providers
-// claimed.
-var allCreators = []repository.ServiceCreator{
-	github.NewRepoServiceIfMatches(),
-	gitlab.NewRepoServiceIfMatches(),
-	generic.NewRepoServiceIfMatches(),
+// allCreators is tried, in order, for every source: every provider
+// registered via git.RegisterProvider (github, gitlab, bitbucket, each
+// self-registering via its package's init() through the blank imports
+// above), followed by the generic-git fallback, which matches any source
+// none of the hosted providers claimed.
+//
+// It's computed once here for this package's own tests, which only ever
+// exercise providers registered by the blank imports above (all of which
+// have already run their init() by the time this var is initialized).
+// DetectBuildEnvironments and DetectBuildEnvironmentsWithInspection don't
+// read it, though: they call registryCreators() fresh on every invocation
+// instead, since a downstream consumer's own provider - registered from its
+// own init() - may not exist yet at the time this package's var initializers
+// run, and Go doesn't guarantee a useful order between them.
+var allCreators = registryCreators()
+
+func registryCreators() []repository.ServiceCreator {
+	providers := git.Providers()
+	creators := make([]repository.ServiceCreator, 0, len(providers))
+	for _, provider := range providers {
+		creators = append(creators, provider.ServiceCreatorFor())
+	}
+	return creators
+}
+
+// fullTreeScanCreators is used instead of allCreators when
+// DetectorOption WithFullTreeScan was given, bypassing the hosted-provider
+// APIs entirely in favour of a shallow, in-memory clone.
+var fullTreeScanCreators = []repository.ServiceCreator{
+	shallow.NewRepoServiceIfMatches(),
 }
 
 // DetectBuildEnvironments inspects source and reports the build tools and
 // languages it can detect through whichever RepositoryService matches it.
 func DetectBuildEnvironments(source *git.Source) (*BuildEnvStats, error) {
-	return detectBuildEnvs(source, allCreators)
+	return detectBuildEnvs(source, registryCreators())
+}
+
+// DetectBuildEnvironmentsWithInspection behaves like DetectBuildEnvironments,
+// plus whatever deeper, more expensive inspection opts opt into (eg. fetching
+// and parsing each detected build tool's manifest file via
+// WithManifestInspection).
+func DetectBuildEnvironmentsWithInspection(source *git.Source, opts ...DetectorOption) (*BuildEnvStats, error) {
+	options := collectOptions(opts)
+
+	creators := registryCreators()
+	if options.fullTreeScan {
+		creators = fullTreeScanCreators
+	}
+	return detectBuildEnvsWithOptions(source, creators, options)
 }
 
 func detectBuildEnvs(source *git.Source, creators []repository.ServiceCreator) (*BuildEnvStats, error) {
+	return detectBuildEnvsWithOptions(source, creators, nil)
+}
+
+func detectBuildEnvsWithOptions(source *git.Source, creators []repository.ServiceCreator, opts *detectOptions) (*BuildEnvStats, error) {
 	for _, create := range creators {
 		service, err := create(source, source.Secret)
 		if err != nil {
 			return nil, err
 		}
 		if service != nil {
-			return detectBuildEnvsUsingService(service)
+			return detectBuildEnvsUsingServiceWithOptions(service, opts)
 		}
 	}
 	return nil, fmt.Errorf("no repository service was able to handle source %s", source.URL)
 }
 
 func detectBuildEnvsUsingService(service repository.RepositoryService) (*BuildEnvStats, error) {
-	files, err := service.GetListOfFilesInRootDir()
+	return detectBuildEnvsUsingServiceWithOptions(service, nil)
+}
+
+// listFiles returns the files to match against buildToolsByFile: the full
+// tree when a full-tree scan was requested and service supports listing it
+// (see repository.FullTreeService), the root directory otherwise.
+func listFiles(service repository.RepositoryService, opts *detectOptions) ([]string, error) {
+	if opts != nil && opts.fullTreeScan {
+		if fullTree, ok := service.(repository.FullTreeService); ok {
+			return fullTree.GetAllFiles()
+		}
+	}
+	return service.GetListOfFilesInRootDir()
+}
+
+func detectBuildEnvsUsingServiceWithOptions(service repository.RepositoryService, opts *detectOptions) (*BuildEnvStats, error) {
+	files, err := listFiles(service, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -92,9 +160,18 @@ func detectBuildEnvsUsingService(service repository.RepositoryService) (*BuildEn
 
 	var buildTools []*BuildTool
 	for _, file := range files {
-		if name, ok := buildToolsByFile[file]; ok {
-			buildTools = append(buildTools, &BuildTool{Name: name, File: file})
+		name, ok := buildToolsByFile[filepath.Base(file)]
+		if !ok {
+			continue
 		}
+
+		buildTool := &BuildTool{Name: name, File: file}
+		if opts != nil && opts.inspectManifests {
+			if err := inspectManifest(service, buildTool); err != nil {
+				return nil, err
+			}
+		}
+		buildTools = append(buildTools, buildTool)
 	}
 
 	return &BuildEnvStats{
@@ -102,3 +179,19 @@ func detectBuildEnvsUsingService(service repository.RepositoryService) (*BuildEn
 		SortedLanguages:    languages,
 	}, nil
 }
+
+// inspectManifest fetches buildTool.File through service and parses it into
+// buildTool.Manifest.
+func inspectManifest(service repository.RepositoryService, buildTool *BuildTool) error {
+	content, err := service.GetFileContents(buildTool.File)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := parseManifest(buildTool.Name, content)
+	if err != nil {
+		return err
+	}
+	buildTool.Manifest = manifest
+	return nil
+}
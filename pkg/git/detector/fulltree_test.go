@@ -0,0 +1,56 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/redhat-developer/git-service/pkg/git"
+	"github.com/redhat-developer/git-service/pkg/git/repository"
+	"github.com/redhat-developer/git-service/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectBuildEnvsWithFullTreeScan(t *testing.T) {
+	// given
+	dummyRepo := test.NewDummyGitRepo(t, repository.Master)
+	dummyRepo.Commit(
+		"pom.xml", "package.json", "src/main/java/Any.java", "src/main/java/Another.java", "pkg/main.go")
+
+	source := &git.Source{URL: dummyRepo.Path}
+
+	// when
+	buildEnvStats, err := DetectBuildEnvironmentsWithInspection(source, WithFullTreeScan())
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, buildEnvStats)
+
+	buildTools := buildEnvStats.DetectedBuildTools
+	require.Len(t, buildTools, 2)
+	assertContainsBuildTool(t, buildTools, Maven, "pom.xml")
+	assertContainsBuildTool(t, buildTools, NodeJS, "package.json")
+
+	langs := buildEnvStats.SortedLanguages
+	assert.Contains(t, langs, "Go")
+	assert.Contains(t, langs, "Java")
+}
+
+func TestDetectBuildEnvsWithFullTreeScanFindsNestedManifests(t *testing.T) {
+	// given
+	dummyRepo := test.NewDummyGitRepo(t, repository.Master)
+	dummyRepo.Commit("backend/pom.xml", "frontend/package.json", "README.md")
+
+	source := &git.Source{URL: dummyRepo.Path}
+
+	// when
+	buildEnvStats, err := DetectBuildEnvironmentsWithInspection(source, WithFullTreeScan())
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, buildEnvStats)
+
+	buildTools := buildEnvStats.DetectedBuildTools
+	require.Len(t, buildTools, 2)
+	assertContainsBuildTool(t, buildTools, Maven, "backend/pom.xml")
+	assertContainsBuildTool(t, buildTools, NodeJS, "frontend/package.json")
+}
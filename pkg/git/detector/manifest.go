@@ -0,0 +1,175 @@
+package detector
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// MavenManifest is the parsed subset of a pom.xml that identifies a Maven
+// module and its direct dependencies.
+type MavenManifest struct {
+	GroupID      string
+	ArtifactID   string
+	Version      string
+	Dependencies []string
+}
+
+// NodeJSManifest is the parsed subset of a package.json that identifies a
+// NodeJS module, its npm scripts and its runtime dependencies.
+type NodeJSManifest struct {
+	Name         string
+	Scripts      map[string]string
+	Dependencies map[string]string
+}
+
+// GoManifest is the parsed subset of a go.mod that identifies a Go module
+// and its require directives.
+type GoManifest struct {
+	Module   string
+	Requires []string
+}
+
+// DockerManifest is the parsed subset of a Dockerfile that identifies its
+// base image and the ports it exposes.
+type DockerManifest struct {
+	BaseImage    string
+	ExposedPorts []string
+}
+
+type pomXML struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Deps       struct {
+		Dependency []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Version    string `xml:"version"`
+		} `xml:"dependency"`
+	} `xml:"dependencies"`
+}
+
+func parseMavenManifest(content []byte) (*MavenManifest, error) {
+	var parsed pomXML
+	if err := xml.Unmarshal(content, &parsed); err != nil {
+		return nil, fmt.Errorf("pom.xml: %w", err)
+	}
+
+	manifest := &MavenManifest{
+		GroupID:    parsed.GroupID,
+		ArtifactID: parsed.ArtifactID,
+		Version:    parsed.Version,
+	}
+	for _, dep := range parsed.Deps.Dependency {
+		manifest.Dependencies = append(manifest.Dependencies, fmt.Sprintf("%s:%s:%s", dep.GroupID, dep.ArtifactID, dep.Version))
+	}
+	return manifest, nil
+}
+
+func parseNodeJSManifest(content []byte) (*NodeJSManifest, error) {
+	var parsed struct {
+		Name         string            `json:"name"`
+		Scripts      map[string]string `json:"scripts"`
+		Dependencies map[string]string `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		return nil, fmt.Errorf("package.json: %w", err)
+	}
+
+	return &NodeJSManifest{
+		Name:         parsed.Name,
+		Scripts:      parsed.Scripts,
+		Dependencies: parsed.Dependencies,
+	}, nil
+}
+
+// parseGoManifest hand-parses the module and require directives out of a
+// go.mod, rather than pulling in golang.org/x/mod/modfile for a handful of
+// lines this project cares about.
+func parseGoManifest(content []byte) (*GoManifest, error) {
+	manifest := &GoManifest{}
+
+	inRequireBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch {
+		case inRequireBlock:
+			if line == ")" {
+				inRequireBlock = false
+				continue
+			}
+			manifest.Requires = append(manifest.Requires, stripTrailingComment(line))
+		case strings.HasPrefix(line, "module "):
+			manifest.Module = strings.TrimSpace(strings.TrimPrefix(line, "module "))
+		case line == "require (":
+			inRequireBlock = true
+		case strings.HasPrefix(line, "require "):
+			manifest.Requires = append(manifest.Requires, stripTrailingComment(strings.TrimPrefix(line, "require ")))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("go.mod: %w", err)
+	}
+	return manifest, nil
+}
+
+func stripTrailingComment(line string) string {
+	if idx := strings.Index(line, "//"); idx >= 0 {
+		line = line[:idx]
+	}
+	return strings.TrimSpace(line)
+}
+
+// parseDockerManifest hand-parses the base image and exposed ports out of a
+// Dockerfile, picking up the last FROM (the final build stage).
+func parseDockerManifest(content []byte) (*DockerManifest, error) {
+	manifest := &DockerManifest{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(strings.TrimSpace(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "FROM":
+			if len(fields) > 1 {
+				manifest.BaseImage = fields[1]
+			}
+		case "EXPOSE":
+			manifest.ExposedPorts = append(manifest.ExposedPorts, fields[1:]...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Dockerfile: %w", err)
+	}
+	return manifest, nil
+}
+
+// parseManifest dispatches to the parser matching name (one of the
+// buildToolsByFile values), returning a nil manifest for build tools this
+// detector doesn't know how to parse deeper than their presence.
+func parseManifest(name string, content []byte) (interface{}, error) {
+	switch name {
+	case Maven:
+		return parseMavenManifest(content)
+	case NodeJS:
+		return parseNodeJSManifest(content)
+	case Go:
+		return parseGoManifest(content)
+	case Docker:
+		return parseDockerManifest(content)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,117 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/redhat-developer/git-service/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMavenManifest(t *testing.T) {
+	// given
+	pom := `<project>
+  <groupId>org.acme</groupId>
+  <artifactId>demo</artifactId>
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>lib</artifactId>
+      <version>2.0.0</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+	// when
+	manifest, err := parseMavenManifest([]byte(pom))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "org.acme", manifest.GroupID)
+	assert.Equal(t, "demo", manifest.ArtifactID)
+	assert.Equal(t, "1.0.0", manifest.Version)
+	assert.Equal(t, []string{"org.acme:lib:2.0.0"}, manifest.Dependencies)
+}
+
+func TestParseNodeJSManifest(t *testing.T) {
+	// given
+	packageJSON := `{
+  "name": "demo",
+  "scripts": {"start": "node index.js"},
+  "dependencies": {"express": "^4.0.0"}
+}`
+
+	// when
+	manifest, err := parseNodeJSManifest([]byte(packageJSON))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "demo", manifest.Name)
+	assert.Equal(t, map[string]string{"start": "node index.js"}, manifest.Scripts)
+	assert.Equal(t, map[string]string{"express": "^4.0.0"}, manifest.Dependencies)
+}
+
+func TestParseGoManifest(t *testing.T) {
+	// given
+	goMod := `module github.com/redhat-developer/git-service
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.6.1
+	golang.org/x/oauth2 v0.0.0 // indirect
+)
+
+require github.com/pkg/errors v0.9.1
+`
+
+	// when
+	manifest, err := parseGoManifest([]byte(goMod))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/redhat-developer/git-service", manifest.Module)
+	assert.Equal(t, []string{
+		"github.com/stretchr/testify v1.6.1",
+		"golang.org/x/oauth2 v0.0.0",
+		"github.com/pkg/errors v0.9.1",
+	}, manifest.Requires)
+}
+
+func TestParseDockerManifest(t *testing.T) {
+	// given
+	dockerfile := `FROM golang:1.15 AS builder
+WORKDIR /src
+FROM alpine:3.12
+EXPOSE 8080
+EXPOSE 9090 9091
+`
+
+	// when
+	manifest, err := parseDockerManifest([]byte(dockerfile))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "alpine:3.12", manifest.BaseImage)
+	assert.Equal(t, []string{"8080", "9090", "9091"}, manifest.ExposedPorts)
+}
+
+func TestDetectBuildEnvsUsingServiceWithManifestInspection(t *testing.T) {
+	// given
+	service := test.NewDummyService("with-manifests", false, test.S("pom.xml"), test.S("Java"))
+	service.Contents = map[string][]byte{
+		"pom.xml": []byte(`<project><groupId>org.acme</groupId><artifactId>demo</artifactId><version>1.0.0</version></project>`),
+	}
+
+	// when
+	buildEnvStats, err := detectBuildEnvsUsingServiceWithOptions(service, &detectOptions{inspectManifests: true})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, buildEnvStats.DetectedBuildTools, 1)
+
+	manifest, ok := buildEnvStats.DetectedBuildTools[0].Manifest.(*MavenManifest)
+	require.True(t, ok)
+	assert.Equal(t, "demo", manifest.ArtifactID)
+}
@@ -0,0 +1,35 @@
+package detector
+
+// DetectorOption configures the optional, more expensive inspection
+// performed by DetectBuildEnvironmentsWithInspection.
+type DetectorOption func(*detectOptions)
+
+type detectOptions struct {
+	inspectManifests bool
+	fullTreeScan     bool
+}
+
+// WithManifestInspection makes DetectBuildEnvironmentsWithInspection fetch
+// the contents of each detected build tool's manifest file through the
+// matching RepositoryService and parse it into BuildTool.Manifest.
+func WithManifestInspection() DetectorOption {
+	return func(o *detectOptions) { o.inspectManifests = true }
+}
+
+// WithFullTreeScan makes DetectBuildEnvironmentsWithInspection bypass the
+// hosted-provider APIs entirely and shallow-clone the source in-memory via
+// the shallow package instead, trading extra clone bandwidth for a
+// full-tree file listing and a byte-count-per-extension language histogram
+// instead of whichever hosted provider's own (often root-dir-only or
+// single-language) view.
+func WithFullTreeScan() DetectorOption {
+	return func(o *detectOptions) { o.fullTreeScan = true }
+}
+
+func collectOptions(opts []DetectorOption) *detectOptions {
+	o := &detectOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,43 @@
+// Package metrics collects Prometheus metrics describing the calls
+// RepositoryService implementations make to their underlying provider: how
+// many requests each provider served, and the most recently observed
+// API rate-limit-remaining value reported back by providers that expose one
+// (GitHub's X-RateLimit-Remaining, GitLab's RateLimit-Remaining).
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// CallsTotal counts calls made to a provider's API, labelled by
+	// provider name and outcome ("success" or "error").
+	CallsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "git_service_provider_calls_total",
+		Help: "Number of calls made to a Git provider's API, by provider and outcome.",
+	}, []string{"provider", "outcome"})
+
+	// RateLimitRemaining tracks the most recently observed rate-limit
+	// remaining value reported by a provider, labelled by provider name.
+	RateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "git_service_provider_rate_limit_remaining",
+		Help: "Most recently observed API rate-limit-remaining value reported by a provider.",
+	}, []string{"provider"})
+)
+
+func init() {
+	prometheus.MustRegister(CallsTotal, RateLimitRemaining)
+}
+
+// ObserveCall records the outcome of a single call made to provider.
+func ObserveCall(provider string, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	CallsTotal.WithLabelValues(provider, outcome).Inc()
+}
+
+// ObserveRateLimitRemaining records the most recent rate-limit-remaining
+// value provider reported.
+func ObserveRateLimitRemaining(provider string, remaining float64) {
+	RateLimitRemaining.WithLabelValues(provider).Set(remaining)
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// RoundTripper wraps an http.RoundTripper, recording a provider API call and
+// (when the response carries it) its rate-limit-remaining header for every
+// request it makes. Providers install it on the http.Client their SDK uses.
+type RoundTripper struct {
+	// Provider is the label every metric recorded through this
+	// RoundTripper is tagged with, eg. "github" or "gitlab".
+	Provider string
+
+	// HeaderName is the response header holding the rate-limit-remaining
+	// value, eg. "X-RateLimit-Remaining". Left empty, rate limits aren't
+	// recorded.
+	HeaderName string
+
+	// Next is the underlying RoundTripper. http.DefaultTransport is used
+	// when nil.
+	Next http.RoundTripper
+}
+
+func (t *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	ObserveCall(t.Provider, err)
+
+	if err == nil && t.HeaderName != "" {
+		if remaining, parseErr := strconv.ParseFloat(resp.Header.Get(t.HeaderName), 64); parseErr == nil {
+			ObserveRateLimitRemaining(t.Provider, remaining)
+		}
+	}
+	return resp, err
+}
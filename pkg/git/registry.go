@@ -0,0 +1,72 @@
+package git
+
+// Matcher decides whether a registered provider should handle source,
+// optionally returning the canonical base URL its RepositoryService should
+// target (eg. so a single on-prem GitLab/GitHub Enterprise provider can
+// serve any hostname, not just the public SaaS one). It receives the same
+// source/secret pair a ServiceCreator would.
+type Matcher func(source *Source, secret Secret) (matches bool, baseURL string)
+
+// Provider is a single registry entry: a name, the Matcher that decides
+// whether it applies, and the ServiceCreator it guards.
+type Provider struct {
+	Name    string
+	Matcher Matcher
+	Creator ServiceCreator
+}
+
+var (
+	providers         []*Provider
+	fallbackProviders []*Provider
+)
+
+// RegisterProvider adds a named provider to the registry. Providers are
+// tried in registration order, and all of them before any provider
+// registered via RegisterFallbackProvider. This lets downstream users add
+// support for self-hosted Gitea, Azure DevOps, or corporate GitLab/GitHub
+// Enterprise variants without forking git-service: call RegisterProvider
+// from an init() function (commonly in a blank import) and it's picked up
+// automatically.
+func RegisterProvider(name string, matcher Matcher, creator ServiceCreator) {
+	providers = append(providers, &Provider{Name: name, Matcher: matcher, Creator: creator})
+}
+
+// RegisterFallbackProvider adds a named provider the same way
+// RegisterProvider does, except it's only tried once every provider
+// registered via RegisterProvider has refused the source. The generic-git
+// package registers itself this way, so it only catches sources nothing
+// more specific claimed.
+func RegisterFallbackProvider(name string, matcher Matcher, creator ServiceCreator) {
+	fallbackProviders = append(fallbackProviders, &Provider{Name: name, Matcher: matcher, Creator: creator})
+}
+
+// Providers returns every registered provider: regular providers first (in
+// registration order), followed by fallback providers (in registration
+// order).
+func Providers() []*Provider {
+	all := make([]*Provider, 0, len(providers)+len(fallbackProviders))
+	all = append(all, providers...)
+	all = append(all, fallbackProviders...)
+	return all
+}
+
+// ServiceCreatorFor adapts p into a plain ServiceCreator: it checks
+// p.Matcher first, and when it matches, delegates to p.Creator with
+// Source.BaseURL set to whatever the matcher returned (unless the caller
+// already set one explicitly).
+func (p *Provider) ServiceCreatorFor() ServiceCreator {
+	return func(source *Source, secret Secret) (RepositoryService, error) {
+		matches, baseURL := p.Matcher(source, secret)
+		if !matches {
+			return nil, nil
+		}
+
+		effectiveSource := source
+		if baseURL != "" && source.BaseURL == "" {
+			clone := *source
+			clone.BaseURL = baseURL
+			effectiveSource = &clone
+		}
+		return p.Creator(effectiveSource, secret)
+	}
+}
@@ -0,0 +1,57 @@
+package git
+
+// RepositoryService provides read-only access to the metadata of a single
+// repository hosted on a specific Git provider (or checked out locally via
+// plain git).
+type RepositoryService interface {
+	// GetListOfFilesInRootDir returns the names of the files located in the
+	// root directory (or Source.ContextDir, if set) of the inspected ref.
+	GetListOfFilesInRootDir() ([]string, error)
+
+	// GetLanguageList returns the languages the provider has detected for
+	// the repository, ordered however the provider returns them.
+	GetLanguageList() ([]string, error)
+
+	// GetFileContents returns the raw contents of the file at path, relative
+	// to the root directory (or Source.ContextDir, if set) of the inspected
+	// ref.
+	GetFileContents(path string) ([]byte, error)
+
+	// GetCommitHistory returns up to limit historical file contents across
+	// the repository's commit history, most recent first. It exists mainly
+	// for deep secret-scanning (see pkg/git/scanner), where a credential
+	// removed from the current tree may still be reachable through history.
+	// Implementations for which walking history isn't cheap or possible (eg.
+	// a shallow, single-commit clone) may return a nil slice and a nil error
+	// rather than an error; callers should treat that as "not supported",
+	// not "no secrets found".
+	GetCommitHistory(limit int) ([]CommitFile, error)
+}
+
+// CommitFile is the content of a single file as it existed in one specific
+// historical commit, returned by RepositoryService.GetCommitHistory.
+type CommitFile struct {
+	Commit   string
+	Path     string
+	Contents []byte
+}
+
+// ServiceCreator attempts to create a RepositoryService for the given
+// source/secret pair. It returns a nil service and a nil error when the
+// source doesn't belong to the provider the creator represents, so callers
+// can try the next creator in line.
+type ServiceCreator func(source *Source, secret Secret) (RepositoryService, error)
+
+// FullTreeService is implemented by a RepositoryService that can list every
+// file in the repository rather than just its root directory. It's an
+// optional, provider-specific capability - most RepositoryServices only
+// ever see the root directory - so it's a separate interface rather than
+// another RepositoryService method: callers that need it (eg. the
+// detector's full-tree scan mode) type-assert for it instead of requiring
+// every implementer to support it.
+type FullTreeService interface {
+	// GetAllFiles returns the paths of every file in the repository (or
+	// Source.ContextDir, if set), relative to it, found by walking the
+	// full tree.
+	GetAllFiles() ([]string, error)
+}
@@ -0,0 +1,160 @@
+package bitbucket_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/redhat-developer/git-service/pkg/git"
+	"github.com/redhat-developer/git-service/pkg/git/repository/bitbucket"
+	"github.com/redhat-developer/git-service/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const (
+	workspace   = "some-org"
+	repoSlug    = "some-repo"
+	repoURL     = "https://bitbucket.org/" + workspace + "/" + repoSlug
+	pathToTestDir = "../../../test"
+	notFound    = `{"error":{"message":"Repository some-org/some-repo not found"}}`
+)
+
+func TestRepositoryServiceForBothAuthMethodsSuccessful(t *testing.T) {
+	// given
+	defer gock.OffAll()
+	usernamePassword := git.NewUsernamePassword("anonymous", "app-password")
+	oauthToken := git.NewOauthToken([]byte("some-token"))
+
+	for _, secret := range []git.Secret{usernamePassword, oauthToken} {
+		mockSrcListing(t, workspace, repoSlug, "master", "pom.xml", "mvnw")
+		mockRepoDetails(t, workspace, repoSlug, "java")
+		source := test.NewGitSource(test.WithURL(repoURL))
+
+		// when
+		service, err := bitbucket.NewRepoServiceIfMatches()(source, secret)
+
+		// then
+		require.NoError(t, err)
+
+		filesInRootDir, err := service.GetListOfFilesInRootDir()
+		require.NoError(t, err)
+		require.Len(t, filesInRootDir, 2)
+		assert.Contains(t, filesInRootDir, "pom.xml")
+		assert.Contains(t, filesInRootDir, "mvnw")
+
+		languageList, err := service.GetLanguageList()
+		require.NoError(t, err)
+		require.Len(t, languageList, 1)
+		assert.Equal(t, "Java", languageList[0])
+	}
+}
+
+func TestGetFileContents(t *testing.T) {
+	// given
+	defer gock.OffAll()
+	oauthToken := git.NewOauthToken([]byte("some-token"))
+	gock.New("https://api.bitbucket.org").
+		Get(fmt.Sprintf("/2.0/repositories/%s/%s/src/master/pom.xml", workspace, repoSlug)).
+		Reply(200).
+		BodyString("<project/>")
+	source := test.NewGitSource(test.WithURL(repoURL))
+
+	// when
+	service, err := bitbucket.NewRepoServiceIfMatches()(source, oauthToken)
+	require.NoError(t, err)
+	content, err := service.GetFileContents("pom.xml")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "<project/>", string(content))
+}
+
+func TestNewRepoServiceIfMatchesShouldNotMatchWhenSshKey(t *testing.T) {
+	// given
+	source := test.NewGitSource(test.WithURL("git@bitbucket.org:" + workspace + "/" + repoSlug))
+
+	// when
+	service, err := bitbucket.NewRepoServiceIfMatches()(source,
+		git.NewSshKey(test.PrivateWithoutPassphrase(t, pathToTestDir), []byte("")))
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, service)
+}
+
+func TestNewRepoServiceIfMatchesShouldNotMatchWhenBitbucketHost(t *testing.T) {
+	// given
+	source := test.NewGitSource(test.WithURL("bitbucket.org/" + workspace + "/" + repoSlug))
+
+	// when
+	service, err := bitbucket.NewRepoServiceIfMatches()(source, git.NewOauthToken([]byte("some-token")))
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, service)
+}
+
+func TestNewRepoServiceIfMatchesShouldMatchWhenFlavorIsBitbucket(t *testing.T) {
+	// given
+	source := test.NewGitSource(
+		test.WithURL("bitbucket.example.com/"+workspace+"/"+repoSlug), test.WithFlavor("bitbucket"))
+
+	// when
+	service, err := bitbucket.NewRepoServiceIfMatches()(source, git.NewOauthToken([]byte("some-token")))
+
+	// then
+	assert.NoError(t, err)
+	assert.NotNil(t, service)
+}
+
+func TestRepositoryServiceForWrongRepo(t *testing.T) {
+	// given
+	defer gock.OffAll()
+	oauthToken := git.NewOauthToken([]byte("some-token"))
+	gock.New("https://api.bitbucket.org").
+		Get(fmt.Sprintf("/2.0/repositories/%s/%s/src/dev/", workspace, repoSlug)).
+		Reply(404).
+		BodyString(notFound)
+	source := test.NewGitSource(test.WithURL(repoURL), test.WithRef("dev"))
+
+	// when
+	service, err := bitbucket.NewRepoServiceIfMatches()(source, oauthToken)
+
+	// then
+	require.NoError(t, err)
+
+	filesInRootDir, err := service.GetListOfFilesInRootDir()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	require.Len(t, filesInRootDir, 0)
+}
+
+func mockSrcListing(t *testing.T, workspace, repoSlug, ref string, files ...string) {
+	type entry struct {
+		Type string `json:"type"`
+		Path string `json:"path"`
+	}
+	var values []entry
+	for _, file := range files {
+		values = append(values, entry{Type: "commit_file", Path: file})
+	}
+	bytes, err := json.Marshal(map[string]interface{}{"values": values})
+	require.NoError(t, err)
+
+	gock.New("https://api.bitbucket.org").
+		Get(fmt.Sprintf("/2.0/repositories/%s/%s/src/%s/", workspace, repoSlug, ref)).
+		Reply(200).
+		BodyString(string(bytes))
+}
+
+func mockRepoDetails(t *testing.T, workspace, repoSlug, language string) {
+	bytes, err := json.Marshal(map[string]string{"language": language})
+	require.NoError(t, err)
+
+	gock.New("https://api.bitbucket.org").
+		Get(fmt.Sprintf("/2.0/repositories/%s/%s", workspace, repoSlug)).
+		Reply(200).
+		BodyString(string(bytes))
+}
@@ -12,6 +12,8 @@ import (
 	"sort"
 
 	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
@@ -19,6 +21,17 @@ import (
 	"github.com/redhat-developer/git-service/pkg/git/repository"
 )
 
+func init() {
+	git.RegisterFallbackProvider("generic", matches, NewRepoServiceIfMatches())
+}
+
+// matches always reports a match, since this package is registered as a
+// fallback provider: the registry only tries it once every dedicated
+// hosted-provider package has already refused the source.
+func matches(source *git.Source, secret git.Secret) (bool, string) {
+	return true, ""
+}
+
 type Service struct {
 	clonePath  string
 	contextDir string
@@ -117,3 +130,60 @@ func (s *Service) GetLanguageList() ([]string, error) {
 	})
 	return languages, nil
 }
+
+// GetFileContents returns the contents of the file at path, relative to
+// s.contextDir, read directly from the local clone's working tree.
+func (s *Service) GetFileContents(path string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(s.clonePath, s.contextDir, path))
+}
+
+// GetCommitHistory returns the contents of every file tracked in each of the
+// up to limit most recent commits reachable from HEAD, walked directly from
+// the local clone's object store.
+func (s *Service) GetCommitHistory(limit int) ([]repository.CommitFile, error) {
+	repo, err := gogit.PlainOpen(s.clonePath)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := repo.Log(&gogit.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []repository.CommitFile
+	count := 0
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if limit > 0 && count >= limit {
+			return storer.ErrStop
+		}
+		count++
+
+		tree, err := commit.Tree()
+		if err != nil {
+			return err
+		}
+
+		return tree.Files().ForEach(func(f *object.File) error {
+			contents, err := f.Contents()
+			if err != nil {
+				return nil
+			}
+			files = append(files, repository.CommitFile{
+				Commit:   commit.Hash.String(),
+				Path:     f.Name,
+				Contents: []byte(contents),
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -5,15 +5,41 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"path"
 	"sort"
 
 	"github.com/google/go-github/v32/github"
 	"github.com/redhat-developer/git-service/pkg/git"
+	"github.com/redhat-developer/git-service/pkg/git/metrics"
 	"github.com/redhat-developer/git-service/pkg/git/repository"
 	"golang.org/x/oauth2"
 )
 
-const defaultHost = "github.com"
+const (
+	defaultHost              = "github.com"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+)
+
+func init() {
+	git.RegisterProvider("github", matches, NewRepoServiceIfMatches())
+}
+
+// matches is the git.Matcher counterpart of the matching logic
+// NewRepoServiceIfMatches' ServiceCreator performs; it's registered
+// separately so the registry can decide whether to try this provider at all
+// without constructing a client.
+func matches(source *git.Source, secret git.Secret) (bool, string) {
+	if secret != nil && secret.Type() == git.SSHKeyType {
+		return false, ""
+	}
+	host, _ := repository.ParseHostAndPath(source.URL)
+	if host != defaultHost && source.Flavor != "github" {
+		return false, ""
+	}
+	return true, ""
+}
 
 type Service struct {
 	client     *github.Client
@@ -53,16 +79,24 @@ func NewRepoServiceIfMatches() repository.ServiceCreator {
 }
 
 func newClient(secret git.Secret) *github.Client {
+	var httpClient *http.Client
 	switch s := secret.(type) {
 	case *git.OauthToken:
 		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: string(s.Token)})
-		return github.NewClient(oauth2.NewClient(context.Background(), ts))
+		httpClient = oauth2.NewClient(context.Background(), ts)
 	case *git.UsernamePassword:
 		tr := &github.BasicAuthTransport{Username: s.Username, Password: s.Password}
-		return github.NewClient(tr.Client())
+		httpClient = tr.Client()
 	default:
-		return github.NewClient(nil)
+		httpClient = &http.Client{}
+	}
+
+	httpClient.Transport = &metrics.RoundTripper{
+		Provider:   "github",
+		HeaderName: rateLimitRemainingHeader,
+		Next:       httpClient.Transport,
 	}
+	return github.NewClient(httpClient)
 }
 
 func splitOwnerRepo(path string) (owner, repo string, err error) {
@@ -101,3 +135,61 @@ func (s *Service) GetLanguageList() ([]string, error) {
 	sort.Slice(result, func(i, j int) bool { return languages[result[i]] > languages[result[j]] })
 	return result, nil
 }
+
+// GetFileContents returns the decoded contents of the file at path, relative
+// to s.contextDir.
+func (s *Service) GetFileContents(filePath string) ([]byte, error) {
+	fileContent, _, _, err := s.client.Repositories.GetContents(
+		context.Background(), s.owner, s.repo, path.Join(s.contextDir, filePath),
+		&github.RepositoryContentGetOptions{Ref: s.ref})
+	if err != nil {
+		return nil, err
+	}
+	if fileContent == nil {
+		return nil, fmt.Errorf("github: %s is not a file", filePath)
+	}
+
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+// GetCommitHistory returns the contents of every file changed across the up
+// to limit most recent commits reachable from s.ref.
+func (s *Service) GetCommitHistory(limit int) ([]repository.CommitFile, error) {
+	commits, _, err := s.client.Repositories.ListCommits(context.Background(), s.owner, s.repo,
+		&github.CommitsListOptions{SHA: s.ref, ListOptions: github.ListOptions{PerPage: limit}})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []repository.CommitFile
+	for _, commit := range commits {
+		detail, _, err := s.client.Repositories.GetCommit(context.Background(), s.owner, s.repo, commit.GetSHA())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range detail.Files {
+			fileContent, _, _, err := s.client.Repositories.GetContents(
+				context.Background(), s.owner, s.repo, path.Join(s.contextDir, file.GetFilename()),
+				&github.RepositoryContentGetOptions{Ref: commit.GetSHA()})
+			if err != nil || fileContent == nil {
+				continue
+			}
+
+			content, err := fileContent.GetContent()
+			if err != nil {
+				continue
+			}
+			files = append(files, repository.CommitFile{
+				Commit:   commit.GetSHA(),
+				Path:     file.GetFilename(),
+				Contents: []byte(content),
+			})
+		}
+	}
+	return files, nil
+}
@@ -6,14 +6,44 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"path"
 
 	"github.com/redhat-developer/git-service/pkg/git"
+	"github.com/redhat-developer/git-service/pkg/git/metrics"
 	"github.com/redhat-developer/git-service/pkg/git/repository"
 	gogl "github.com/xanzy/go-gitlab"
 	"golang.org/x/oauth2"
 )
 
-const defaultHost = "gitlab.com"
+const (
+	defaultHost              = "gitlab.com"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+)
+
+func init() {
+	git.RegisterProvider("gitlab", matches, NewRepoServiceIfMatches())
+}
+
+// matches is the git.Matcher counterpart of the matching logic
+// NewRepoServiceIfMatches' ServiceCreator performs; it's registered
+// separately so the registry can decide whether to try this provider at all
+// without constructing a client. It reports the https://<host> base URL so
+// a single registered GitLab provider can serve any on-prem hostname
+// reached through Source.Flavor == "gitlab", not just gitlab.com.
+func matches(source *git.Source, secret git.Secret) (bool, string) {
+	if secret != nil && secret.Type() == git.SSHKeyType {
+		return false, ""
+	}
+	host, _ := repository.ParseHostAndPath(source.URL)
+	if host != defaultHost && source.Flavor != "gitlab" {
+		return false, ""
+	}
+	if host == "" {
+		host = defaultHost
+	}
+	return true, fmt.Sprintf("https://%s", host)
+}
 
 type Service struct {
 	client     *gogl.Client
@@ -26,7 +56,8 @@ type Service struct {
 // RepositoryService for sources pointing at gitlab.com, or any host when
 // Source.Flavor is explicitly set to "gitlab" (self-hosted instances). It
 // never matches an SSH secret, since the GitLab REST API is only reachable
-// over HTTPS.
+// over HTTPS. Source.BaseURL, when set, overrides the derived API base URL,
+// letting a single GitLab provider serve arbitrary on-prem hostnames.
 func NewRepoServiceIfMatches() repository.ServiceCreator {
 	return func(source *git.Source, secret git.Secret) (repository.RepositoryService, error) {
 		if secret != nil && secret.Type() == git.SSHKeyType {
@@ -41,7 +72,12 @@ func NewRepoServiceIfMatches() repository.ServiceCreator {
 			host = defaultHost
 		}
 
-		client, err := newClient(fmt.Sprintf("https://%s", host), secret)
+		baseURL := fmt.Sprintf("https://%s", host)
+		if source.BaseURL != "" {
+			baseURL = source.BaseURL
+		}
+
+		client, err := newClient(baseURL, secret)
 		if err != nil {
 			return nil, err
 		}
@@ -63,16 +99,20 @@ func refOrDefault(ref string) string {
 }
 
 func newClient(baseURL string, secret git.Secret) (*gogl.Client, error) {
+	httpClient := &http.Client{
+		Transport: &metrics.RoundTripper{Provider: "gitlab", HeaderName: rateLimitRemainingHeader},
+	}
+
 	switch s := secret.(type) {
 	case *git.OauthToken:
-		return gogl.NewOAuthClient(string(s.Token), gogl.WithBaseURL(baseURL))
+		return gogl.NewOAuthClient(string(s.Token), gogl.WithBaseURL(baseURL), gogl.WithHTTPClient(httpClient))
 	case *git.UsernamePassword:
 		conf := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: baseURL + "/oauth/token"}}
 		token, err := conf.PasswordCredentialsToken(context.Background(), s.Username, s.Password)
 		if err != nil {
 			return nil, err
 		}
-		return gogl.NewOAuthClient(token.AccessToken, gogl.WithBaseURL(baseURL))
+		return gogl.NewOAuthClient(token.AccessToken, gogl.WithBaseURL(baseURL), gogl.WithHTTPClient(httpClient))
 	default:
 		return nil, fmt.Errorf("gitlab: unsupported secret type %T", secret)
 	}
@@ -109,3 +149,52 @@ func (s *Service) GetLanguageList() ([]string, error) {
 	}
 	return result, nil
 }
+
+// GetFileContents returns the raw contents of the file at path, relative to
+// s.contextDir.
+func (s *Service) GetFileContents(filePath string) ([]byte, error) {
+	content, _, err := s.client.RepositoryFiles.GetRawFile(s.project, path.Join(s.contextDir, filePath),
+		&gogl.GetRawFileOptions{Ref: gogl.String(s.ref)})
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// GetCommitHistory returns the contents of every file changed across the up
+// to limit most recent commits reachable from s.ref.
+func (s *Service) GetCommitHistory(limit int) ([]repository.CommitFile, error) {
+	commits, _, err := s.client.Commits.ListCommits(s.project, &gogl.ListCommitsOptions{
+		RefName:     gogl.String(s.ref),
+		ListOptions: gogl.ListOptions{PerPage: limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []repository.CommitFile
+	for _, commit := range commits {
+		diffs, _, err := s.client.Commits.GetCommitDiff(s.project, commit.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, diff := range diffs {
+			if diff.DeletedFile {
+				continue
+			}
+
+			content, _, err := s.client.RepositoryFiles.GetRawFile(s.project, diff.NewPath,
+				&gogl.GetRawFileOptions{Ref: gogl.String(commit.ID)})
+			if err != nil {
+				continue
+			}
+			files = append(files, repository.CommitFile{
+				Commit:   commit.ID,
+				Path:     diff.NewPath,
+				Contents: content,
+			})
+		}
+	}
+	return files, nil
+}
@@ -55,6 +55,28 @@ func TestRepositoryServiceForBothAuthMethodsSuccessful(t *testing.T) {
 	}
 }
 
+func TestGetFileContents(t *testing.T) {
+	// given
+	defer gock.OffAll()
+	oauthToken := git.NewOauthToken([]byte("some-token"))
+	mockTokenCall(t)
+	gock.New("https://gitlab.com").
+		Get(fmt.Sprintf("/api/v4/projects/%s/repository/files/pom.xml/raw", repoIdentifier)).
+		MatchParam("ref", "master").
+		Reply(200).
+		BodyString("<project/>")
+	source := test.NewGitSource(test.WithURL(repoURL))
+
+	// when
+	service, err := gitlab.NewRepoServiceIfMatches()(source, oauthToken)
+	require.NoError(t, err)
+	content, err := service.GetFileContents("pom.xml")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "<project/>", string(content))
+}
+
 func TestNewRepoServiceIfMatchesShouldNotMatchWhenSshKey(t *testing.T) {
 	// given
 	source := test.NewGitSource(test.WithURL("git@gitlab.com:" + repoIdentifier))
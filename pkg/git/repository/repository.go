@@ -6,21 +6,12 @@ import "github.com/redhat-developer/git-service/pkg/git"
 // repositories the test helpers spin up.
 const Master = "master"
 
-// RepositoryService provides read-only access to the metadata of a single
-// repository hosted on a specific Git provider (or checked out locally via
-// plain git).
-type RepositoryService interface {
-	// GetListOfFilesInRootDir returns the names of the files located in the
-	// root directory (or Source.ContextDir, if set) of the inspected ref.
-	GetListOfFilesInRootDir() ([]string, error)
-
-	// GetLanguageList returns the languages the provider has detected for
-	// the repository, ordered however the provider returns them.
-	GetLanguageList() ([]string, error)
-}
-
-// ServiceCreator attempts to create a RepositoryService for the given
-// source/secret pair. It returns a nil service and a nil error when the
-// source doesn't belong to the provider the creator represents, so callers
-// can try the next creator in line.
-type ServiceCreator func(source *git.Source, secret git.Secret) (RepositoryService, error)
+// RepositoryService, ServiceCreator and CommitFile now live in package git,
+// so that git.RegisterProvider (and the registry built on top of it) can
+// reference them without an import cycle back into this package. These
+// aliases keep every existing call site in this tree (and any downstream
+// user's) working unchanged.
+type RepositoryService = git.RepositoryService
+type ServiceCreator = git.ServiceCreator
+type CommitFile = git.CommitFile
+type FullTreeService = git.FullTreeService
@@ -0,0 +1,18 @@
+package shallow
+
+// extensionLanguages is a small, hand-maintained subset of the mapping
+// GitHub Linguist uses to turn a file extension into a language name. It only
+// needs to cover the languages this project's detector cares about.
+var extensionLanguages = map[string]string{
+	".go":   "Go",
+	".java": "Java",
+	".kt":   "Kotlin",
+	".js":   "JavaScript",
+	".ts":   "TypeScript",
+	".py":   "Python",
+	".rb":   "Ruby",
+	".json": "JSON",
+	".xml":  "XML",
+	".yaml": "YAML",
+	".yml":  "YAML",
+}
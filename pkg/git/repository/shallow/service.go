@@ -0,0 +1,196 @@
+// Package shallow implements the repository.RepositoryService by performing
+// a shallow, in-memory clone of the source with go-git instead of talking to
+// a hosted provider's API. Unlike the hosted providers (which only see the
+// root directory) it walks the whole tree, trading extra clone bandwidth for
+// a Linguist-style, byte-count-per-extension language histogram computed
+// over every file rather than whatever the provider reports.
+package shallow
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	billyutil "github.com/go-git/go-billy/v5/util"
+
+	"github.com/redhat-developer/git-service/pkg/git"
+	"github.com/redhat-developer/git-service/pkg/git/repository"
+)
+
+type Service struct {
+	fs         billy.Filesystem
+	contextDir string
+}
+
+// NewRepoServiceIfMatches returns a ServiceCreator that shallow-clones
+// source.URL into an in-memory billy filesystem and returns a Service backed
+// by the resulting working tree. It matches any source, the same way the
+// generic package does, so it's only meant to be used on its own (selected
+// via detector.WithFullTreeScan), never mixed into the hosted-provider
+// creator chain.
+func NewRepoServiceIfMatches() repository.ServiceCreator {
+	return func(source *git.Source, secret git.Secret) (repository.RepositoryService, error) {
+		auth, err := authMethod(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		fs := memfs.New()
+		_, err = gogit.CloneContext(context.Background(), memory.NewStorage(), fs, &gogit.CloneOptions{
+			URL:           source.URL,
+			Auth:          auth,
+			Depth:         1,
+			SingleBranch:  true,
+			NoCheckout:    false,
+			ReferenceName: referenceName(source.Ref),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &Service{fs: fs, contextDir: source.ContextDir}, nil
+	}
+}
+
+func referenceName(ref string) plumbing.ReferenceName {
+	if ref == "" {
+		return ""
+	}
+	return plumbing.NewBranchReferenceName(ref)
+}
+
+// rootRelative returns "/" for an empty contextDir, since billy filesystems
+// (unlike a plain OS path) don't resolve "" to their own root.
+func rootRelative(contextDir string) string {
+	if contextDir == "" {
+		return "/"
+	}
+	return contextDir
+}
+
+func authMethod(secret git.Secret) (transport.AuthMethod, error) {
+	switch s := secret.(type) {
+	case *git.SshKey:
+		return gitssh.NewPublicKeys("git", s.PrivateKey, string(s.Passphrase))
+	case *git.OauthToken:
+		return &githttp.BasicAuth{Username: "oauth2", Password: string(s.Token)}, nil
+	case *git.UsernamePassword:
+		return &githttp.BasicAuth{Username: s.Username, Password: s.Password}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (s *Service) GetListOfFilesInRootDir() ([]string, error) {
+	entries, err := s.fs.ReadDir(rootRelative(s.contextDir))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		files = append(files, entry.Name())
+	}
+	return files, nil
+}
+
+// GetAllFiles walks the full clone and returns the path of every file,
+// relative to s.contextDir, implementing repository.FullTreeService so the
+// detector's full-tree scan mode can find build-tool manifests no matter
+// how deeply they're nested, unlike GetListOfFilesInRootDir.
+func (s *Service) GetAllFiles() ([]string, error) {
+	root := rootRelative(s.contextDir)
+
+	var files []string
+	err := billyutil.Walk(s.fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// GetLanguageList walks the full clone and returns the languages recognised
+// via extensionLanguages, ordered by the total number of bytes found in
+// files of that language, largest first.
+func (s *Service) GetLanguageList() ([]string, error) {
+	bytesByLanguage := map[string]int64{}
+
+	err := billyutil.Walk(s.fs, "/", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		language, ok := extensionLanguages[filepath.Ext(path)]
+		if !ok {
+			return nil
+		}
+		bytesByLanguage[language] += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]string, 0, len(bytesByLanguage))
+	for language := range bytesByLanguage {
+		languages = append(languages, language)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		return bytesByLanguage[languages[i]] > bytesByLanguage[languages[j]]
+	})
+	return languages, nil
+}
+
+func (s *Service) GetFileContents(path string) ([]byte, error) {
+	f, err := s.fs.Open(filepath.Join(s.contextDir, path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
+
+// GetCommitHistory isn't implemented: the clone is fetched at Depth: 1, so
+// only the checked-out commit's objects are ever present locally. It reports
+// history as unsupported rather than erroring.
+func (s *Service) GetCommitHistory(limit int) ([]repository.CommitFile, error) {
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"math"
+	"regexp"
+)
+
+// highEntropyThreshold is the Shannon-entropy-per-character cutoff above
+// which a base64/hex-charset run is flagged as a likely secret, rather than
+// ordinary prose or structured (low-entropy) data.
+const highEntropyThreshold = 4.5
+
+// minHighEntropyRunLength is the shortest substring highEntropyStrings will
+// consider; shorter runs don't carry enough signal to tell a secret apart
+// from coincidental high-entropy text.
+const minHighEntropyRunLength = 20
+
+var (
+	base64Charset = regexp.MustCompile(`[A-Za-z0-9+/]{20,}`)
+	hexCharset    = regexp.MustCompile(`[0-9a-fA-F]{20,}`)
+)
+
+// shannonEntropy returns the Shannon entropy of s, in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+
+	length := float64(len(s))
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// highEntropyStrings returns every base64 or hex-charset substring of line
+// at least minHighEntropyRunLength characters long whose Shannon entropy
+// exceeds highEntropyThreshold.
+func highEntropyStrings(line string) []string {
+	var matches []string
+	for _, charset := range []*regexp.Regexp{base64Charset, hexCharset} {
+		for _, candidate := range charset.FindAllString(line, -1) {
+			if shannonEntropy(candidate) > highEntropyThreshold {
+				matches = append(matches, candidate)
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,30 @@
+// Package scanner inspects a Git source for accidentally committed secrets,
+// reusing the same RepositoryService abstraction the detector package is
+// built on: it works against whatever hosted-provider API matches the
+// source (root dir, or with WithHistory, commit history), or a full
+// shallow clone via WithFullTreeScan for breadth across every file in the
+// tree. A shallow clone only ever has the single checked-out commit's
+// objects, though, so it can't support WithHistory's deep commit-history
+// scanning - that still needs a hosted provider or the generic-git
+// fallback.
+package scanner
+
+import "strings"
+
+// Finding is a single potential secret ScanSource located.
+type Finding struct {
+	File         string
+	Line         int
+	DetectorName string
+	Redacted     string
+	Verified     bool
+}
+
+// redact returns secret with everything but its first and last 4 characters
+// replaced by "*", so a Finding never carries the live credential it flagged.
+func redact(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", len(secret)-8) + secret[len(secret)-4:]
+}
@@ -0,0 +1,44 @@
+package scanner
+
+// ScanOption configures a ScanSource call.
+type ScanOption func(*scanOptions)
+
+type scanOptions struct {
+	detectors    []Detector
+	historyLimit int
+	fullTreeScan bool
+}
+
+// WithDetectors overrides the detectors ScanSource runs against each line,
+// replacing DefaultDetectors entirely. The high-entropy scan always runs
+// regardless.
+func WithDetectors(detectors ...Detector) ScanOption {
+	return func(o *scanOptions) { o.detectors = detectors }
+}
+
+// WithHistory makes ScanSource also scan up to limit historical file
+// contents via the matching RepositoryService's GetCommitHistory, for
+// providers that support it. Without this option, only the current root
+// directory listing is scanned.
+func WithHistory(limit int) ScanOption {
+	return func(o *scanOptions) { o.historyLimit = limit }
+}
+
+// WithFullTreeScan makes ScanSource bypass the hosted-provider APIs
+// entirely and shallow-clone the source in-memory via the shallow package
+// instead, trading extra clone bandwidth for every file in the tree rather
+// than just the root directory. It has no effect on WithHistory: a shallow
+// clone only ever has the single checked-out commit's objects, so
+// shallow.Service.GetCommitHistory reports history as unsupported rather
+// than scanning it.
+func WithFullTreeScan() ScanOption {
+	return func(o *scanOptions) { o.fullTreeScan = true }
+}
+
+func collectOptions(opts []ScanOption) *scanOptions {
+	options := &scanOptions{detectors: DefaultDetectors}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
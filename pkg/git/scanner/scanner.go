@@ -0,0 +1,120 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+
+	"github.com/redhat-developer/git-service/pkg/git"
+	"github.com/redhat-developer/git-service/pkg/git/repository"
+	"github.com/redhat-developer/git-service/pkg/git/repository/shallow"
+)
+
+// ScanSource inspects source's current root directory (or, with
+// WithFullTreeScan, every file in the tree) - and, with WithHistory, its
+// commit history where the matching RepositoryService supports it - for
+// accidentally committed secrets, using DefaultDetectors unless
+// WithDetectors overrides them.
+func ScanSource(source *git.Source, opts ...ScanOption) ([]Finding, error) {
+	options := collectOptions(opts)
+
+	service, err := findService(source, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+
+	files, err := listFiles(service, options)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		content, err := service.GetFileContents(file)
+		if err != nil {
+			// Unreadable (eg. binary, or a directory entry the provider
+			// still listed) files are skipped rather than failing the scan.
+			continue
+		}
+		findings = append(findings, scanContent(file, content, options.detectors)...)
+	}
+
+	if options.historyLimit > 0 {
+		history, err := service.GetCommitHistory(options.historyLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, commitFile := range history {
+			findings = append(findings, scanContent(commitFile.Path, commitFile.Contents, options.detectors)...)
+		}
+	}
+
+	return findings, nil
+}
+
+// findService returns the RepositoryService ScanSource should use: a
+// shallow, in-memory clone when WithFullTreeScan was given, or otherwise
+// the first hosted-provider (or generic-git fallback) match, tried in the
+// same order the detector package would.
+func findService(source *git.Source, options *scanOptions) (repository.RepositoryService, error) {
+	if options.fullTreeScan {
+		return shallow.NewRepoServiceIfMatches()(source, source.Secret)
+	}
+
+	for _, provider := range git.Providers() {
+		service, err := provider.ServiceCreatorFor()(source, source.Secret)
+		if err != nil {
+			return nil, err
+		}
+		if service != nil {
+			return service, nil
+		}
+	}
+	return nil, fmt.Errorf("no repository service was able to handle source %s", source.URL)
+}
+
+// listFiles returns the files ScanSource should scan: every file in the
+// tree when WithFullTreeScan was given and service supports listing it (see
+// repository.FullTreeService), the root directory otherwise.
+func listFiles(service repository.RepositoryService, options *scanOptions) ([]string, error) {
+	if options.fullTreeScan {
+		if fullTree, ok := service.(repository.FullTreeService); ok {
+			return fullTree.GetAllFiles()
+		}
+	}
+	return service.GetListOfFilesInRootDir()
+}
+
+// scanContent runs detectors and the high-entropy scan against every line of
+// content, reporting file as the Finding.File for each match.
+func scanContent(file string, content []byte, detectors []Detector) []Finding {
+	var findings []Finding
+
+	lineScanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNum := 0
+	for lineScanner.Scan() {
+		lineNum++
+		line := lineScanner.Text()
+
+		for _, detector := range detectors {
+			for _, match := range detector.Pattern.FindAllString(line, -1) {
+				findings = append(findings, newFinding(file, lineNum, detector.Name, match, detector.Verifier))
+			}
+		}
+
+		for _, match := range highEntropyStrings(line) {
+			findings = append(findings, newFinding(file, lineNum, highEntropyDetectorName, match, nil))
+		}
+	}
+
+	return findings
+}
+
+func newFinding(file string, line int, detectorName, secret string, verify Verifier) Finding {
+	finding := Finding{File: file, Line: line, DetectorName: detectorName, Redacted: redact(secret)}
+	if verify != nil {
+		verified, err := verify(secret)
+		finding.Verified = err == nil && verified
+	}
+	return finding
+}
@@ -22,6 +22,14 @@ type Source struct {
 	// name doesn't match the public SaaS host.
 	Flavor string
 
+	// BaseURL overrides the canonical API base URL a matching provider's
+	// RepositoryService client targets, eg. "https://git.example.com" for a
+	// corporate GitLab instance. Providers that support it use this instead
+	// of deriving a base URL from URL/Flavor; it's otherwise left for the
+	// provider to figure out. A Matcher registered via RegisterProvider can
+	// also supply one, which is used unless BaseURL is already set here.
+	BaseURL string
+
 	// Secret carries the credentials (if any) used to authenticate against
 	// the repository.
 	Secret Secret
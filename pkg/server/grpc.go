@@ -0,0 +1,12 @@
+package server
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/redhat-developer/git-service/pkg/server/proto"
+)
+
+// RegisterGRPC registers srv as the DetectionService implementation on s.
+func RegisterGRPC(s *grpc.Server, srv *Server) {
+	proto.RegisterDetectionServiceServer(s, srv)
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/redhat-developer/git-service/pkg/server/proto"
+	"github.com/redhat-developer/git-service/pkg/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGRPCDetectRoundTrip drives a real grpc.Server/grpc.ClientConn pair
+// end-to-end, rather than calling Server.Detect directly, so that
+// DetectRequest's oneof Credentials field actually gets marshaled and
+// unmarshaled over the wire.
+func TestGRPCDetectRoundTrip(t *testing.T) {
+	// given
+	currentDummy = test.NewDummyService(serverDummyFlavor, false, test.S("pom.xml"), test.S("Java"))
+
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	RegisterGRPC(grpcServer, NewServer())
+	go func() { _ = grpcServer.Serve(lis) }()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := proto.NewDetectionServiceClient(conn)
+
+	// when
+	stats, err := client.Detect(context.Background(), &proto.DetectRequest{
+		Flavor: serverDummyFlavor,
+		Credentials: &proto.DetectRequest_UsernamePassword{
+			UsernamePassword: &proto.UsernamePassword{Username: []byte("user"), Password: []byte("pass")},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, stats.DetectedBuildTools, 1)
+	require.Equal(t, "Maven", stats.DetectedBuildTools[0].Name)
+}
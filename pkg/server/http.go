@@ -0,0 +1,86 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/redhat-developer/git-service/pkg/server/proto"
+)
+
+// detectRequestBody is the JSON shape POST /v1/detect accepts. Credentials
+// are carried as base64-encoded bytes (encoding/json's default for []byte),
+// so callers can pass the contents of a Kubernetes Secret directly.
+type detectRequestBody struct {
+	URL    string `json:"url"`
+	Ref    string `json:"ref,omitempty"`
+	Flavor string `json:"flavor,omitempty"`
+
+	OauthToken       []byte            `json:"oauth_token,omitempty"`
+	UsernamePassword *usernamePassword `json:"username_password,omitempty"`
+	SshKey           *sshKey           `json:"ssh_key,omitempty"`
+}
+
+type usernamePassword struct {
+	Username []byte `json:"username"`
+	Password []byte `json:"password"`
+}
+
+type sshKey struct {
+	PrivateKey []byte `json:"private_key"`
+	Passphrase []byte `json:"passphrase"`
+}
+
+func (b *detectRequestBody) toProtoRequest() *proto.DetectRequest {
+	req := &proto.DetectRequest{Url: b.URL, Ref: b.Ref, Flavor: b.Flavor}
+
+	switch {
+	case b.UsernamePassword != nil:
+		req.Credentials = &proto.DetectRequest_UsernamePassword{
+			UsernamePassword: &proto.UsernamePassword{
+				Username: b.UsernamePassword.Username,
+				Password: b.UsernamePassword.Password,
+			},
+		}
+	case b.SshKey != nil:
+		req.Credentials = &proto.DetectRequest_SshKey{
+			SshKey: &proto.SshKey{PrivateKey: b.SshKey.PrivateKey, Passphrase: b.SshKey.Passphrase},
+		}
+	case len(b.OauthToken) > 0:
+		req.Credentials = &proto.DetectRequest_OauthToken{OauthToken: b.OauthToken}
+	}
+
+	return req
+}
+
+// NewHTTPHandler returns an http.Handler serving POST /v1/detect, backed by
+// srv. The request/response bodies are JSON, mirroring detection.proto's
+// DetectRequest/BuildEnvStats messages.
+func NewHTTPHandler(srv *Server) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/detect", handleDetect(srv))
+	return mux
+}
+
+func handleDetect(srv *Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body detectRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		stats, err := srv.Detect(r.Context(), body.toProtoRequest())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(stats)
+	}
+}
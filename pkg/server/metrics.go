@@ -0,0 +1,15 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewMetricsHandler returns an http.Handler serving the Prometheus metrics
+// registered by pkg/git/metrics: per-provider call counts and the most
+// recently observed rate-limit-remaining value for providers that report
+// one.
+func NewMetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
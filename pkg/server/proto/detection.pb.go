@@ -0,0 +1,255 @@
+// Package proto holds the Go types for detection.proto's messages and
+// service. It's hand-maintained, not protoc-gen-go output: there's no
+// protoc/protoc-gen-go toolchain wired into this repo (no go:generate, no
+// Makefile target, no CI step) to regenerate it from, so it only implements
+// the legacy Reset/String/ProtoMessage trio plus the XXX_OneofWrappers
+// method the proto runtime's legacy-message support needs for the
+// Credentials oneof. Keep it in sync with detection.proto by hand, and
+// don't regenerate it with a real protoc-gen-go without carrying the
+// XXX_OneofWrappers method forward - dropping it reintroduces a marshal
+// panic on DetectRequest.Credentials.
+package proto
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+type DetectRequest struct {
+	Url    string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Ref    string `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Flavor string `protobuf:"bytes,3,opt,name=flavor,proto3" json:"flavor,omitempty"`
+
+	// Types that are valid to be assigned to Credentials:
+	//	*DetectRequest_OauthToken
+	//	*DetectRequest_UsernamePassword
+	//	*DetectRequest_SshKey
+	Credentials isDetectRequest_Credentials `protobuf_oneof:"credentials"`
+}
+
+func (m *DetectRequest) Reset()         { *m = DetectRequest{} }
+func (m *DetectRequest) String() string { return proto.CompactTextString(m) }
+func (*DetectRequest) ProtoMessage()    {}
+
+type isDetectRequest_Credentials interface {
+	isDetectRequest_Credentials()
+}
+
+type DetectRequest_OauthToken struct {
+	OauthToken []byte `protobuf:"bytes,4,opt,name=oauth_token,json=oauthToken,proto3,oneof"`
+}
+
+type DetectRequest_UsernamePassword struct {
+	UsernamePassword *UsernamePassword `protobuf:"bytes,5,opt,name=username_password,json=usernamePassword,proto3,oneof"`
+}
+
+type DetectRequest_SshKey struct {
+	SshKey *SshKey `protobuf:"bytes,6,opt,name=ssh_key,json=sshKey,proto3,oneof"`
+}
+
+func (*DetectRequest_OauthToken) isDetectRequest_Credentials()       {}
+func (*DetectRequest_UsernamePassword) isDetectRequest_Credentials() {}
+func (*DetectRequest_SshKey) isDetectRequest_Credentials()           {}
+
+func (m *DetectRequest) GetCredentials() isDetectRequest_Credentials {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+func (m *DetectRequest) GetOauthToken() []byte {
+	if x, ok := m.GetCredentials().(*DetectRequest_OauthToken); ok {
+		return x.OauthToken
+	}
+	return nil
+}
+
+func (m *DetectRequest) GetUsernamePassword() *UsernamePassword {
+	if x, ok := m.GetCredentials().(*DetectRequest_UsernamePassword); ok {
+		return x.UsernamePassword
+	}
+	return nil
+}
+
+func (m *DetectRequest) GetSshKey() *SshKey {
+	if x, ok := m.GetCredentials().(*DetectRequest_SshKey); ok {
+		return x.SshKey
+	}
+	return nil
+}
+
+// XXX_OneofWrappers lists the concrete types that can be assigned to
+// Credentials, for the proto runtime's legacy-message reflection support.
+func (*DetectRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*DetectRequest_OauthToken)(nil),
+		(*DetectRequest_UsernamePassword)(nil),
+		(*DetectRequest_SshKey)(nil),
+	}
+}
+
+type UsernamePassword struct {
+	Username []byte `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password []byte `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (m *UsernamePassword) Reset()         { *m = UsernamePassword{} }
+func (m *UsernamePassword) String() string { return proto.CompactTextString(m) }
+func (*UsernamePassword) ProtoMessage()    {}
+
+type SshKey struct {
+	PrivateKey []byte `protobuf:"bytes,1,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	Passphrase []byte `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+}
+
+func (m *SshKey) Reset()         { *m = SshKey{} }
+func (m *SshKey) String() string { return proto.CompactTextString(m) }
+func (*SshKey) ProtoMessage()    {}
+
+type BuildTool struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	File string `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+}
+
+func (m *BuildTool) Reset()         { *m = BuildTool{} }
+func (m *BuildTool) String() string { return proto.CompactTextString(m) }
+func (*BuildTool) ProtoMessage()    {}
+
+type BuildEnvStats struct {
+	DetectedBuildTools []*BuildTool `protobuf:"bytes,1,rep,name=detected_build_tools,json=detectedBuildTools,proto3" json:"detected_build_tools,omitempty"`
+	SortedLanguages    []string     `protobuf:"bytes,2,rep,name=sorted_languages,json=sortedLanguages,proto3" json:"sorted_languages,omitempty"`
+}
+
+func (m *BuildEnvStats) Reset()         { *m = BuildEnvStats{} }
+func (m *BuildEnvStats) String() string { return proto.CompactTextString(m) }
+func (*BuildEnvStats) ProtoMessage()    {}
+
+// DetectionServiceClient is the client API for DetectionService.
+type DetectionServiceClient interface {
+	Detect(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (*BuildEnvStats, error)
+	DetectStream(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (DetectionService_DetectStreamClient, error)
+}
+
+type detectionServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDetectionServiceClient(cc *grpc.ClientConn) DetectionServiceClient {
+	return &detectionServiceClient{cc}
+}
+
+func (c *detectionServiceClient) Detect(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (*BuildEnvStats, error) {
+	out := new(BuildEnvStats)
+	if err := c.cc.Invoke(ctx, "/server.DetectionService/Detect", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectionServiceClient) DetectStream(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (DetectionService_DetectStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DetectionService_serviceDesc.Streams[0], "/server.DetectionService/DetectStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &detectionServiceDetectStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// DetectionService_DetectStreamClient is the client-side stream returned by
+// DetectStream.
+type DetectionService_DetectStreamClient interface {
+	Recv() (*BuildEnvStats, error)
+	grpc.ClientStream
+}
+
+type detectionServiceDetectStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *detectionServiceDetectStreamClient) Recv() (*BuildEnvStats, error) {
+	m := new(BuildEnvStats)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DetectionServiceServer is the server API for DetectionService.
+type DetectionServiceServer interface {
+	Detect(context.Context, *DetectRequest) (*BuildEnvStats, error)
+	DetectStream(*DetectRequest, DetectionService_DetectStreamServer) error
+}
+
+// DetectionService_DetectStreamServer is the server-side stream DetectStream
+// implementations send results to.
+type DetectionService_DetectStreamServer interface {
+	Send(*BuildEnvStats) error
+	grpc.ServerStream
+}
+
+type detectionServiceDetectStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *detectionServiceDetectStreamServer) Send(m *BuildEnvStats) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DetectionService_Detect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectionServiceServer).Detect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.DetectionService/Detect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectionServiceServer).Detect(ctx, req.(*DetectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectionService_DetectStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DetectRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DetectionServiceServer).DetectStream(m, &detectionServiceDetectStreamServer{stream})
+}
+
+var _DetectionService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "server.DetectionService",
+	HandlerType: (*DetectionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Detect",
+			Handler:    _DetectionService_Detect_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DetectStream",
+			Handler:       _DetectionService_DetectStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "detection.proto",
+}
+
+// RegisterDetectionServiceServer registers srv with s.
+func RegisterDetectionServiceServer(s *grpc.Server, srv DetectionServiceServer) {
+	s.RegisterService(&_DetectionService_serviceDesc, srv)
+}
@@ -0,0 +1,96 @@
+// Package server exposes detector.DetectBuildEnvironments over gRPC and
+// REST, translating pkg/server/proto's wire types (see detection.proto) into
+// the git.Source the rest of this module already understands.
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-developer/git-service/pkg/git"
+	"github.com/redhat-developer/git-service/pkg/git/detector"
+	"github.com/redhat-developer/git-service/pkg/server/proto"
+)
+
+// Server implements proto.DetectionServiceServer on top of
+// detector.DetectBuildEnvironments.
+type Server struct{}
+
+// NewServer creates a Server ready to be registered with a grpc.Server (via
+// proto.RegisterDetectionServiceServer) or wrapped by NewHTTPHandler.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Detect runs a single detection pass against the source described by req.
+// Per-provider call counts and rate-limit-remaining metrics are recorded by
+// the matching RepositoryService's own HTTP client (see pkg/git/metrics), so
+// Detect itself doesn't need to know which provider served the request.
+func (s *Server) Detect(ctx context.Context, req *proto.DetectRequest) (*proto.BuildEnvStats, error) {
+	source, err := sourceFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := detector.DetectBuildEnvironments(source)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoStats(stats), nil
+}
+
+// DetectStream behaves like Detect, but delivers its result over stream.
+// Detection itself isn't incremental yet, so today this sends exactly one
+// message; the transport exists ahead of time for the future secret-scanner
+// (pkg/git/scanner), whose findings will arrive one at a time.
+func (s *Server) DetectStream(req *proto.DetectRequest, stream proto.DetectionService_DetectStreamServer) error {
+	stats, err := s.Detect(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return stream.Send(stats)
+}
+
+func sourceFromRequest(req *proto.DetectRequest) (*git.Source, error) {
+	secret, err := secretFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &git.Source{
+		URL:    req.Url,
+		Ref:    req.Ref,
+		Flavor: req.Flavor,
+		Secret: secret,
+	}, nil
+}
+
+func secretFromRequest(req *proto.DetectRequest) (git.Secret, error) {
+	switch credentials := req.GetCredentials().(type) {
+	case nil:
+		return nil, nil
+	case *proto.DetectRequest_OauthToken:
+		return git.NewOauthToken(credentials.OauthToken), nil
+	case *proto.DetectRequest_UsernamePassword:
+		return git.NewUsernamePassword(
+			string(credentials.UsernamePassword.Username),
+			string(credentials.UsernamePassword.Password),
+		), nil
+	case *proto.DetectRequest_SshKey:
+		return git.NewSshKey(credentials.SshKey.PrivateKey, credentials.SshKey.Passphrase), nil
+	default:
+		return nil, fmt.Errorf("server: unsupported credentials type %T", credentials)
+	}
+}
+
+func toProtoStats(stats *detector.BuildEnvStats) *proto.BuildEnvStats {
+	buildTools := make([]*proto.BuildTool, 0, len(stats.DetectedBuildTools))
+	for _, buildTool := range stats.DetectedBuildTools {
+		buildTools = append(buildTools, &proto.BuildTool{Name: buildTool.Name, File: buildTool.File})
+	}
+
+	return &proto.BuildEnvStats{
+		DetectedBuildTools: buildTools,
+		SortedLanguages:    stats.SortedLanguages,
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-developer/git-service/pkg/git"
+	"github.com/redhat-developer/git-service/pkg/git/repository"
+	"github.com/redhat-developer/git-service/pkg/server/proto"
+	"github.com/redhat-developer/git-service/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serverDummyFlavor is registered against the real git.Providers() registry
+// below, the same way a downstream user's own provider package would, so
+// Detect (which always goes through the registry) can be exercised against
+// a DummyService.
+const serverDummyFlavor = "server-dummy"
+
+func init() {
+	git.RegisterProvider(serverDummyFlavor,
+		func(source *git.Source, secret git.Secret) (bool, string) {
+			return source.Flavor == serverDummyFlavor, ""
+		},
+		func(source *git.Source, secret git.Secret) (repository.RepositoryService, error) {
+			return currentDummy.Creator()(source, secret)
+		})
+}
+
+var currentDummy *test.DummyService
+
+func TestServerDetect(t *testing.T) {
+	// given
+	currentDummy = test.NewDummyService(serverDummyFlavor, false, test.S("pom.xml"), test.S("Java"))
+	srv := NewServer()
+
+	// when
+	stats, err := srv.Detect(context.TODO(), &proto.DetectRequest{Flavor: serverDummyFlavor})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, stats.DetectedBuildTools, 1)
+	assert.Equal(t, "Maven", stats.DetectedBuildTools[0].Name)
+	assert.Equal(t, []string{"Java"}, stats.SortedLanguages)
+}
+
+func TestHTTPHandlerDetect(t *testing.T) {
+	// given
+	currentDummy = test.NewDummyService(serverDummyFlavor, false, test.S("pom.xml"), test.S("Java"))
+	handler := NewHTTPHandler(NewServer())
+
+	body, err := json.Marshal(detectRequestBody{Flavor: serverDummyFlavor})
+	require.NoError(t, err)
+
+	// when
+	req := httptest.NewRequest(http.MethodPost, "/v1/detect", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// then
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var stats protoBuildEnvStatsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&stats))
+	require.Len(t, stats.DetectedBuildTools, 1)
+	assert.Equal(t, "Maven", stats.DetectedBuildTools[0].Name)
+}
+
+// protoBuildEnvStatsResponse mirrors proto.BuildEnvStats' JSON shape for
+// decoding the HTTP handler's response without depending on the protobuf
+// field tags directly.
+type protoBuildEnvStatsResponse struct {
+	DetectedBuildTools []struct {
+		Name string `json:"name"`
+		File string `json:"file"`
+	} `json:"detected_build_tools"`
+	SortedLanguages []string `json:"sorted_languages"`
+}
@@ -15,6 +15,8 @@ type DummyService struct {
 	ShouldFail bool
 	Files      SliceOfStrings
 	Langs      SliceOfStrings
+	Contents   map[string][]byte
+	History    []repository.CommitFile
 }
 
 // NewDummyService creates a DummyService that matches sources whose Flavor
@@ -53,3 +55,18 @@ func (d *DummyService) GetLanguageList() ([]string, error) {
 	}
 	return langs, nil
 }
+
+func (d *DummyService) GetFileContents(path string) ([]byte, error) {
+	content, ok := d.Contents[path]
+	if !ok {
+		return nil, errors.New("no content registered for " + path)
+	}
+	return content, nil
+}
+
+func (d *DummyService) GetCommitHistory(limit int) ([]repository.CommitFile, error) {
+	if limit > 0 && limit < len(d.History) {
+		return d.History[:limit], nil
+	}
+	return d.History, nil
+}
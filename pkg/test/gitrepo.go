@@ -48,6 +48,17 @@ func (r *DummyGitRepo) Commit(paths ...string) {
 	runGit(r.t, r.Path, "commit", "-m", "add "+strings.Join(paths, ", "))
 }
 
+// CommitFile writes contents to path (creating any parent directories as
+// needed) and commits it on its own, for tests that need to control a
+// file's actual content rather than Commit's fixed placeholder.
+func (r *DummyGitRepo) CommitFile(path, contents string) {
+	full := filepath.Join(r.Path, path)
+	require.NoError(r.t, os.MkdirAll(filepath.Dir(full), 0755))
+	require.NoError(r.t, ioutil.WriteFile(full, []byte(contents), 0644))
+	runGit(r.t, r.Path, "add", path)
+	runGit(r.t, r.Path, "commit", "-m", "add "+path)
+}
+
 func runGit(t *testing.T, dir string, args ...string) {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
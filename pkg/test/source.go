@@ -26,3 +26,7 @@ func WithRef(ref string) GitSourceOption {
 func WithFlavor(flavor string) GitSourceOption {
 	return func(s *git.Source) { s.Flavor = flavor }
 }
+
+func WithBaseURL(baseURL string) GitSourceOption {
+	return func(s *git.Source) { s.BaseURL = baseURL }
+}